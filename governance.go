@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// UpgradePlan mirrors the `plan` field of the Cosmos SDK
+// /cosmos/upgrade/v1beta1/current_plan response.
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Height string `json:"height"`
+	Info   string `json:"info"`
+}
+
+type currentPlanResponse struct {
+	Plan *UpgradePlan `json:"plan"`
+}
+
+// GovProposal is the subset of a /cosmos/gov/v1beta1/proposals entry we
+// care about: its id, title, message type and when its voting period ends.
+type GovProposal struct {
+	ProposalId    string `json:"proposal_id"`
+	VotingEndTime string `json:"voting_end_time"`
+	Content       struct {
+		Type  string `json:"@type"`
+		Title string `json:"title"`
+	} `json:"content"`
+}
+
+type proposalsResponse struct {
+	Proposals []GovProposal `json:"proposals"`
+}
+
+// GetUpgradePlan queries the Cosmos SDK LCD for the currently scheduled
+// upgrade plan. It returns a nil plan (and nil error) when no upgrade is
+// scheduled.
+func GetUpgradePlan(cosmosRest string) (*UpgradePlan, error) {
+	var response currentPlanResponse
+	if err := getJson(cosmosRest+"/cosmos/upgrade/v1beta1/current_plan", &response); err != nil {
+		return nil, err
+	}
+
+	if response.Plan == nil || response.Plan.Height == "" || response.Plan.Height == "0" {
+		return nil, nil
+	}
+
+	return response.Plan, nil
+}
+
+// GetVotingProposals queries the Cosmos SDK LCD for proposals currently in
+// their voting period (proposal_status=2).
+func GetVotingProposals(cosmosRest string) ([]GovProposal, error) {
+	var response proposalsResponse
+	if err := getJson(cosmosRest+"/cosmos/gov/v1beta1/proposals?proposal_status=2", &response); err != nil {
+		return nil, err
+	}
+
+	return response.Proposals, nil
+}
+
+func getJson(url string, target interface{}) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(target)
+}
+
+// upgradePlanHeight parses the plan's target height. It returns an error if
+// the height cannot be parsed, so callers can skip publishing metrics derived
+// from it rather than reporting a height of 0 as if the upgrade were already
+// overdue.
+func upgradePlanHeight(plan *UpgradePlan) (int64, error) {
+	height, err := strconv.ParseInt(plan.Height, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse upgrade plan height %q: %w", plan.Height, err)
+	}
+
+	return height, nil
+}
+
+// secondsUntilVotingEnd parses a proposal's voting_end_time and returns how
+// many seconds remain until it, returning 0 if it cannot be parsed.
+func secondsUntilVotingEnd(proposal GovProposal) float64 {
+	votingEndTime, err := time.Parse(time.RFC3339, proposal.VotingEndTime)
+	if err != nil {
+		log.Debug().Err(err).Str("voting_end_time", proposal.VotingEndTime).Msg("Could not parse proposal voting end time")
+		return 0
+	}
+
+	return time.Until(votingEndTime).Seconds()
+}
@@ -25,7 +25,8 @@ import (
 )
 
 var (
-	ConfigPath string
+	ConfigPath        string
+	ModulesConfigPath string
 
 	ListenAddress       string
 	LocalTendermintRpc  string
@@ -38,6 +39,26 @@ var (
 	GithubOrg   string
 	GithubRepo  string
 	GithubToken string
+
+	ReleaseSources  []string
+	GitlabProjectId string
+	GitlabBaseUrl   string
+	GitlabToken     string
+	GiteaBaseUrl    string
+	GiteaOwner      string
+	GiteaRepo       string
+	GiteaToken      string
+	ReleaseUrl      string
+	ReleaseJsonPath string
+	HttpToken       string
+
+	CosmosRest string
+
+	ConsiderPrerelease bool
+
+	SigningWindow int64
+
+	Modules map[string]NodeConfig
 )
 
 type VersionInfo struct {
@@ -45,16 +66,15 @@ type VersionInfo struct {
 	Version string `json:"version"`
 }
 
-type ReleaseInfo struct {
-	Name    string `json:"name"`
-	TagName string `json:"tag_name"`
-}
-
 type Data struct {
-	releaseInfo  ReleaseInfo
+	releases     []ReleaseResult
 	versionInfo  VersionInfo
 	localStatus  *coretypes.ResultStatus
 	remoteStatus *coretypes.ResultStatus
+	netInfo      *coretypes.ResultNetInfo
+	mempoolInfo  *coretypes.ResultUnconfirmedTxs
+	upgradePlan  *UpgradePlan
+	govProposals []GovProposal
 	err          error
 }
 
@@ -102,7 +122,17 @@ func Execute(cmd *cobra.Command, args []string) {
 		log = zerolog.New(os.Stdout).With().Timestamp().Logger()
 	}
 
+	if ModulesConfigPath != "" {
+		modules, err := LoadModulesConfig(ModulesConfigPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Could not load modules config")
+		}
+
+		Modules = modules
+	}
+
 	http.HandleFunc("/metrics", Handler)
+	http.HandleFunc("/probe", ProbeHandler)
 
 	log.Info().Str("address", ListenAddress).Msg("Listening")
 	err = http.ListenAndServe(ListenAddress, nil)
@@ -111,7 +141,102 @@ func Execute(cmd *cobra.Command, args []string) {
 	}
 }
 
+// Handler serves /metrics, scraping the node configured at process start via
+// flags or the config file.
 func Handler(w http.ResponseWriter, r *http.Request) {
+	cfg := NodeConfig{
+		RPC:         LocalTendermintRpc,
+		RemoteRPC:   RemoteTendermintRpc,
+		BinaryPath:  BinaryPath,
+		BinaryArgs:  BinaryArgs,
+		GithubOrg:   GithubOrg,
+		GithubRepo:  GithubRepo,
+		GithubToken: GithubToken,
+		CosmosRest:  CosmosRest,
+
+		ReleaseSources:  ReleaseSources,
+		GitlabProjectId: GitlabProjectId,
+		GitlabBaseUrl:   GitlabBaseUrl,
+		GitlabToken:     GitlabToken,
+		GiteaBaseUrl:    GiteaBaseUrl,
+		GiteaOwner:      GiteaOwner,
+		GiteaRepo:       GiteaRepo,
+		GiteaToken:      GiteaToken,
+		ReleaseUrl:      ReleaseUrl,
+		ReleaseJsonPath: ReleaseJsonPath,
+		HttpToken:       HttpToken,
+	}
+
+	CollectAndServe(w, r, cfg)
+}
+
+// ProbeHandler serves /probe, in the style of blackbox_exporter: it builds a
+// NodeConfig from the request's query string on every call, rather than
+// relying on flags fixed at process start. Either a pre-defined `module`
+// (looked up in the modules config file) or ad-hoc query parameters
+// (`target`, `remote`, `binary`, `binary_args`, `github`) can be used to
+// describe the node to scrape. Tokens are accepted only via the modules
+// config, never as a query parameter, since query strings end up in
+// Prometheus target URLs and access logs.
+func ProbeHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var cfg NodeConfig
+	if module := query.Get("module"); module != "" {
+		moduleConfig, ok := Modules[module]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unknown module: " + module))
+			return
+		}
+
+		cfg = moduleConfig
+	} else {
+		cfg = NodeConfig{
+			RPC:        query.Get("target"),
+			RemoteRPC:  query.Get("remote"),
+			BinaryPath: query.Get("binary"),
+			BinaryArgs: query.Get("binary_args"),
+			CosmosRest: query.Get("cosmos_rest"),
+
+			GitlabProjectId: query.Get("gitlab_project_id"),
+			GitlabBaseUrl:   query.Get("gitlab_base_url"),
+			GiteaBaseUrl:    query.Get("gitea_base_url"),
+			GiteaOwner:      query.Get("gitea_owner"),
+			GiteaRepo:       query.Get("gitea_repo"),
+			ReleaseUrl:      query.Get("release_url"),
+			ReleaseJsonPath: query.Get("release_json_path"),
+		}
+
+		if releaseSource := query.Get("release_source"); releaseSource != "" {
+			cfg.ReleaseSources = strings.Split(releaseSource, ",")
+		}
+
+		if github := query.Get("github"); github != "" {
+			org, repo, found := strings.Cut(github, "/")
+			if !found {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("github parameter must be in the org/repo form"))
+				return
+			}
+
+			cfg.GithubOrg = org
+			cfg.GithubRepo = repo
+		}
+	}
+
+	if cfg.RPC == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("target (or a known module) is required"))
+		return
+	}
+
+	CollectAndServe(w, r, cfg)
+}
+
+// CollectAndServe fetches the node's data and renders it into a fresh
+// Prometheus registry, scoped to a single request.
+func CollectAndServe(w http.ResponseWriter, r *http.Request, cfg NodeConfig) {
 	nodeCatchingUpGauge := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_node_catching_up",
@@ -152,6 +277,14 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		[]string{"organization", "repository", "version"},
 	)
 
+	releaseLatestVersion := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_release_latest_version",
+			Help: "Latest version reported by a configured release source",
+		},
+		[]string{"source", "repo", "version"},
+	)
+
 	latestVersionMismatch := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_latest_version_mismatch",
@@ -160,6 +293,22 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		[]string{"id", "moniker", "local_version", "remote_version"},
 	)
 
+	versionDeltaGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_version_delta",
+			Help: "Signed difference between the local and the latest released version, per component",
+		},
+		[]string{"id", "moniker", "kind"},
+	)
+
+	versionStatusGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_version_status",
+			Help: "Set to 1 for the status matching the local version's relation to the latest release",
+		},
+		[]string{"id", "moniker", "status"},
+	)
+
 	localNodeLatestBlock := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_local_node_latest_block",
@@ -176,17 +325,155 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		[]string{"id", "moniker"},
 	)
 
+	validatorMissedBlocksWindow := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_validator_missed_blocks_window",
+			Help: "Number of blocks in the signing window the validator did not sign",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	validatorSignedBlocksWindow := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_validator_signed_blocks_window",
+			Help: "Number of blocks in the signing window the validator signed",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	validatorPrecommitAbsentTotal := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_validator_precommit_absent_total",
+			Help: "Total number of precommits the validator was absent for, since the exporter started tracking it",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	validatorProposedBlocksTotal := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_validator_proposed_blocks_total",
+			Help: "Total number of blocks proposed by each validator seen in the signing window, since the exporter started tracking it",
+		},
+		[]string{"id", "moniker", "proposer"},
+	)
+
+	peersTotal := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_peers_total",
+			Help: "Total number of peers the node is connected to",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	peersInbound := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_peers_inbound",
+			Help: "Number of inbound peers the node is connected to",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	peersOutbound := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_peers_outbound",
+			Help: "Number of outbound peers the node is connected to",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	peerInfo := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_peer_info",
+			Help: "Meta information about a connected peer, always set to 1",
+		},
+		[]string{"id", "moniker", "peer_id", "peer_moniker", "network", "remote_ip", "is_outbound"},
+	)
+
+	peerSendBytesTotal := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_peer_send_bytes_total",
+			Help: "Total bytes sent to a peer",
+		},
+		[]string{"id", "moniker", "peer_id", "peer_moniker"},
+	)
+
+	peerReceiveBytesTotal := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_node_peer_receive_bytes_total",
+			Help: "Total bytes received from a peer",
+		},
+		[]string{"id", "moniker", "peer_id", "peer_moniker"},
+	)
+
+	mempoolSize := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_mempool_size",
+			Help: "Number of unconfirmed transactions in the mempool",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	mempoolBytes := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_mempool_bytes",
+			Help: "Total size in bytes of unconfirmed transactions in the mempool",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	upgradePlanGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_upgrade_plan",
+			Help: "Set to the planned upgrade height when an upgrade plan is scheduled on-chain",
+		},
+		[]string{"id", "moniker", "name", "info"},
+	)
+
+	upgradeBlocksRemaining := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_upgrade_blocks_remaining",
+			Help: "Number of blocks remaining until the scheduled upgrade height",
+		},
+		[]string{"id", "moniker"},
+	)
+
+	govProposalVoting := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_gov_proposal_voting",
+			Help: "Seconds remaining until a governance proposal's voting period ends",
+		},
+		[]string{"id", "moniker", "proposal_id", "title", "type"},
+	)
+
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(nodeCatchingUpGauge)
 	registry.MustRegister(appVersion)
 	registry.MustRegister(votingPower)
 	registry.MustRegister(githubLatestVersion)
+	registry.MustRegister(releaseLatestVersion)
 	registry.MustRegister(latestVersionMismatch)
+	registry.MustRegister(versionDeltaGauge)
+	registry.MustRegister(versionStatusGauge)
 	registry.MustRegister(localNodeLatestBlock)
 	registry.MustRegister(remoteNodeLatestBlock)
 	registry.MustRegister(timeSinceLatestBlock)
-
-	data := GetAllData()
+	registry.MustRegister(validatorMissedBlocksWindow)
+	registry.MustRegister(validatorSignedBlocksWindow)
+	registry.MustRegister(validatorPrecommitAbsentTotal)
+	registry.MustRegister(validatorProposedBlocksTotal)
+	registry.MustRegister(peersTotal)
+	registry.MustRegister(peersInbound)
+	registry.MustRegister(peersOutbound)
+	registry.MustRegister(peerInfo)
+	registry.MustRegister(peerSendBytesTotal)
+	registry.MustRegister(peerReceiveBytesTotal)
+	registry.MustRegister(mempoolSize)
+	registry.MustRegister(mempoolBytes)
+	registry.MustRegister(upgradePlanGauge)
+	registry.MustRegister(upgradeBlocksRemaining)
+	registry.MustRegister(govProposalVoting)
+
+	data := GetAllData(cfg)
 	if data.err != nil {
 		log.Error().Err(data.err).Msg("Could not fetch some data")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -217,23 +504,84 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		}).Set(1)
 	}
 
-	if data.releaseInfo.TagName != "" {
+	for _, release := range data.releases {
+		releaseLatestVersion.With(prometheus.Labels{
+			"source":  release.Source,
+			"repo":    release.Repo,
+			"version": release.Version,
+		}).Set(1)
+	}
+
+	var githubReleaseVersion string
+	for _, release := range data.releases {
+		if release.Source == "github" {
+			githubReleaseVersion = release.Version
+			break
+		}
+	}
+
+	if githubReleaseVersion != "" {
 		githubLatestVersion.With(prometheus.Labels{
-			"organization": GithubOrg,
-			"repository":   GithubRepo,
-			"version":      data.releaseInfo.TagName,
+			"organization": cfg.GithubOrg,
+			"repository":   cfg.GithubRepo,
+			"version":      githubReleaseVersion,
 		}).Set(1)
 	}
 
-	if data.versionInfo.Version != "" && data.releaseInfo.TagName != "" {
-		versionMismatch := !(strings.Contains(data.releaseInfo.TagName, data.versionInfo.Version) || strings.Contains(data.versionInfo.Version, data.releaseInfo.TagName))
+	// The staleness gauges below compare against the first configured release
+	// source that returned a version (a source that failed is skipped), not
+	// specifically GitHub, so they keep working for chains that publish on
+	// GitLab, Gitea, or a custom HTTP source instead.
+	var primaryReleaseVersion string
+	if len(data.releases) > 0 {
+		primaryReleaseVersion = data.releases[0].Version
+	}
 
-		latestVersionMismatch.With(prometheus.Labels{
-			"id":             string(data.localStatus.NodeInfo.DefaultNodeID),
-			"moniker":        data.localStatus.NodeInfo.Moniker,
-			"local_version":  data.versionInfo.Version,
-			"remote_version": data.releaseInfo.TagName,
-		}).Set(BoolToFloat64(versionMismatch))
+	if data.versionInfo.Version != "" && primaryReleaseVersion != "" {
+		id := string(data.localStatus.NodeInfo.DefaultNodeID)
+		moniker := data.localStatus.NodeInfo.Moniker
+
+		localSemver, localErr := parseSemver(data.versionInfo.Version)
+		remoteSemver, remoteErr := parseSemver(primaryReleaseVersion)
+
+		if localErr != nil || remoteErr != nil {
+			log.Debug().
+				AnErr("local_error", localErr).
+				AnErr("remote_error", remoteErr).
+				Str("local_version", data.versionInfo.Version).
+				Str("remote_version", primaryReleaseVersion).
+				Msg("Could not parse version as semver, falling back to substring comparison")
+
+			versionMismatch := !(strings.Contains(primaryReleaseVersion, data.versionInfo.Version) || strings.Contains(data.versionInfo.Version, primaryReleaseVersion))
+
+			latestVersionMismatch.With(prometheus.Labels{
+				"id":             id,
+				"moniker":        moniker,
+				"local_version":  data.versionInfo.Version,
+				"remote_version": primaryReleaseVersion,
+			}).Set(BoolToFloat64(versionMismatch))
+		} else {
+			delta, status := compareSemver(localSemver, remoteSemver, ConsiderPrerelease)
+
+			latestVersionMismatch.With(prometheus.Labels{
+				"id":             id,
+				"moniker":        moniker,
+				"local_version":  data.versionInfo.Version,
+				"remote_version": primaryReleaseVersion,
+			}).Set(BoolToFloat64(status != versionStatusUpToDate))
+
+			versionDeltaGauge.With(prometheus.Labels{"id": id, "moniker": moniker, "kind": "major"}).Set(float64(delta.major))
+			versionDeltaGauge.With(prometheus.Labels{"id": id, "moniker": moniker, "kind": "minor"}).Set(float64(delta.minor))
+			versionDeltaGauge.With(prometheus.Labels{"id": id, "moniker": moniker, "kind": "patch"}).Set(float64(delta.patch))
+
+			for _, candidate := range []versionStatus{versionStatusUpToDate, versionStatusBehind, versionStatusAhead, versionStatusPrerelease} {
+				versionStatusGauge.With(prometheus.Labels{
+					"id":      id,
+					"moniker": moniker,
+					"status":  string(candidate),
+				}).Set(BoolToFloat64(candidate == status))
+			}
+		}
 	}
 
 	localNodeLatestBlock.With(prometheus.Labels{
@@ -248,6 +596,120 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		}).Set(float64(data.remoteStatus.SyncInfo.LatestBlockHeight))
 	}
 
+	if data.localStatus.ValidatorInfo.VotingPower > 0 && SigningWindow > 0 {
+		id := string(data.localStatus.NodeInfo.DefaultNodeID)
+		moniker := data.localStatus.NodeInfo.Moniker
+
+		signing, err := GetValidatorSigningInfo(
+			cfg.RPC,
+			data.localStatus.ValidatorInfo.Address,
+			data.localStatus.SyncInfo.LatestBlockHeight,
+			SigningWindow,
+		)
+		if err != nil {
+			log.Error().Err(err).Msg("Could not fetch validator signing info")
+		} else {
+			validatorMissedBlocksWindow.With(prometheus.Labels{"id": id, "moniker": moniker}).Set(float64(signing.Missed))
+			validatorSignedBlocksWindow.With(prometheus.Labels{"id": id, "moniker": moniker}).Set(float64(signing.Signed))
+			validatorPrecommitAbsentTotal.With(prometheus.Labels{"id": id, "moniker": moniker}).Set(signing.PrecommitAbsentTotal)
+
+			for proposer, count := range signing.ProposedBlocksTotal {
+				validatorProposedBlocksTotal.With(prometheus.Labels{
+					"id":       id,
+					"moniker":  moniker,
+					"proposer": proposer,
+				}).Set(count)
+			}
+		}
+	}
+
+	if data.netInfo != nil {
+		id := string(data.localStatus.NodeInfo.DefaultNodeID)
+		moniker := data.localStatus.NodeInfo.Moniker
+
+		var inbound, outbound int
+
+		for _, peer := range data.netInfo.Peers {
+			if peer.IsOutbound {
+				outbound++
+			} else {
+				inbound++
+			}
+
+			peerInfo.With(prometheus.Labels{
+				"id":           id,
+				"moniker":      moniker,
+				"peer_id":      string(peer.NodeInfo.DefaultNodeID),
+				"peer_moniker": peer.NodeInfo.Moniker,
+				"network":      peer.NodeInfo.Network,
+				"remote_ip":    peer.RemoteIP,
+				"is_outbound":  fmt.Sprintf("%t", peer.IsOutbound),
+			}).Set(1)
+
+			peerSendBytesTotal.With(prometheus.Labels{
+				"id":           id,
+				"moniker":      moniker,
+				"peer_id":      string(peer.NodeInfo.DefaultNodeID),
+				"peer_moniker": peer.NodeInfo.Moniker,
+			}).Set(float64(peer.ConnectionStatus.SendMonitor.Bytes))
+
+			peerReceiveBytesTotal.With(prometheus.Labels{
+				"id":           id,
+				"moniker":      moniker,
+				"peer_id":      string(peer.NodeInfo.DefaultNodeID),
+				"peer_moniker": peer.NodeInfo.Moniker,
+			}).Set(float64(peer.ConnectionStatus.RecvMonitor.Bytes))
+		}
+
+		peersTotal.With(prometheus.Labels{"id": id, "moniker": moniker}).Set(float64(data.netInfo.NPeers))
+		peersInbound.With(prometheus.Labels{"id": id, "moniker": moniker}).Set(float64(inbound))
+		peersOutbound.With(prometheus.Labels{"id": id, "moniker": moniker}).Set(float64(outbound))
+	}
+
+	if data.mempoolInfo != nil {
+		mempoolSize.With(prometheus.Labels{
+			"id":      string(data.localStatus.NodeInfo.DefaultNodeID),
+			"moniker": data.localStatus.NodeInfo.Moniker,
+		}).Set(float64(data.mempoolInfo.Total))
+
+		mempoolBytes.With(prometheus.Labels{
+			"id":      string(data.localStatus.NodeInfo.DefaultNodeID),
+			"moniker": data.localStatus.NodeInfo.Moniker,
+		}).Set(float64(data.mempoolInfo.TotalBytes))
+	}
+
+	if data.upgradePlan != nil {
+		id := string(data.localStatus.NodeInfo.DefaultNodeID)
+		moniker := data.localStatus.NodeInfo.Moniker
+
+		height, err := upgradePlanHeight(data.upgradePlan)
+		if err != nil {
+			log.Error().Err(err).Msg("Could not determine upgrade plan height")
+		} else {
+			upgradePlanGauge.With(prometheus.Labels{
+				"id":      id,
+				"moniker": moniker,
+				"name":    data.upgradePlan.Name,
+				"info":    data.upgradePlan.Info,
+			}).Set(float64(height))
+
+			upgradeBlocksRemaining.With(prometheus.Labels{
+				"id":      id,
+				"moniker": moniker,
+			}).Set(float64(height - data.localStatus.SyncInfo.LatestBlockHeight))
+		}
+	}
+
+	for _, proposal := range data.govProposals {
+		govProposalVoting.With(prometheus.Labels{
+			"id":          string(data.localStatus.NodeInfo.DefaultNodeID),
+			"moniker":     data.localStatus.NodeInfo.Moniker,
+			"proposal_id": proposal.ProposalId,
+			"title":       proposal.Content.Title,
+			"type":        proposal.Content.Type,
+		}).Set(secondsUntilVotingEnd(proposal))
+	}
+
 	h := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 	h.ServeHTTP(w, r)
 }
@@ -260,58 +722,94 @@ func BoolToFloat64(value bool) float64 {
 	return 0
 }
 
-func GetAllData() Data {
+func GetAllData(cfg NodeConfig) Data {
 	var (
 		wg                sync.WaitGroup
 		localStatus       *coretypes.ResultStatus
 		remoteStatus      *coretypes.ResultStatus
-		releaseInfo       ReleaseInfo
+		releases          []ReleaseResult
 		versionInfo       VersionInfo
+		netInfo           *coretypes.ResultNetInfo
+		mempoolInfo       *coretypes.ResultUnconfirmedTxs
+		upgradePlan       *UpgradePlan
+		govProposals      []GovProposal
 		localStatusError  error
 		remoteStatusError error
-		releaseInfoError  error
 		versionInfoError  error
+		netInfoError      error
+		mempoolInfoError  error
+		upgradePlanError  error
+		govProposalsError error
 	)
 
 	go func() {
-		localStatus, localStatusError = GetNodeStatus(LocalTendermintRpc)
+		localStatus, localStatusError = GetNodeStatus(cfg.RPC)
 		wg.Done()
 	}()
 	wg.Add(1)
 
 	go func() {
-		if RemoteTendermintRpc == "" {
+		if cfg.RemoteRPC == "" {
 			log.Debug().Msg("No remote tendermint RPC address set, not requesting its status.")
 			wg.Done()
 			return
 		}
 
-		remoteStatus, remoteStatusError = GetNodeStatus(RemoteTendermintRpc)
+		remoteStatus, remoteStatusError = GetNodeStatus(cfg.RemoteRPC)
+		wg.Done()
+	}()
+	wg.Add(1)
+
+	go func() {
+		releases = FetchReleases(cfg)
 		wg.Done()
 	}()
 	wg.Add(1)
 
 	go func() {
-		if GithubOrg == "" || GithubRepo == "" {
-			log.Debug().Msg("No GitHub org or repo set, not requesting latest binary version.")
+		if cfg.BinaryPath == "" {
+			log.Debug().Msg("Binary path not set, not querying its version.")
 			wg.Done()
 			return
 		}
 
-		latestReleaseUrl := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", GithubOrg, GithubRepo)
-		releaseInfoError = GetGithubRelease(latestReleaseUrl, &releaseInfo)
+		versionInfo, versionInfoError = GetBinaryVersion(cfg.BinaryPath, cfg.BinaryArgs)
 		wg.Done()
 	}()
 	wg.Add(1)
 
 	go func() {
-		if BinaryPath == "" {
-			log.Debug().Msg("Binary path not set, not querying its version.")
+		netInfo, netInfoError = GetNetInfo(cfg.RPC)
+		wg.Done()
+	}()
+	wg.Add(1)
+
+	go func() {
+		mempoolInfo, mempoolInfoError = GetMempoolInfo(cfg.RPC)
+		wg.Done()
+	}()
+	wg.Add(1)
+
+	go func() {
+		if cfg.CosmosRest == "" {
+			log.Debug().Msg("No Cosmos REST address set, not requesting the upgrade plan.")
 			wg.Done()
 			return
 		}
 
-		versionInfo, versionInfoError = GetBinaryVersion()
+		upgradePlan, upgradePlanError = GetUpgradePlan(cfg.CosmosRest)
+		wg.Done()
+	}()
+	wg.Add(1)
+
+	go func() {
+		if cfg.CosmosRest == "" {
+			log.Debug().Msg("No Cosmos REST address set, not requesting governance proposals.")
+			wg.Done()
+			return
+		}
+
+		govProposals, govProposalsError = GetVotingProposals(cfg.CosmosRest)
 		wg.Done()
 	}()
 	wg.Add(1)
@@ -328,43 +826,42 @@ func GetAllData() Data {
 		return Data{err: remoteStatusError}
 	}
 
-	if releaseInfoError != nil {
-		log.Error().Err(releaseInfoError).Msg("Could not fetch latest version")
-		return Data{err: releaseInfoError}
-	}
-
 	if versionInfoError != nil {
 		log.Error().Err(versionInfoError).Msg("Could not fetch app version")
 		return Data{err: versionInfoError}
 	}
 
-	return Data{
-		releaseInfo:  releaseInfo,
-		versionInfo:  versionInfo,
-		localStatus:  localStatus,
-		remoteStatus: remoteStatus,
+	// netInfo, mempoolInfo, upgradePlan and govProposals are all optional
+	// enrichments: a failure fetching one of them is logged and the
+	// corresponding field is left unset, rather than failing the whole
+	// scrape, the same way a single failed ReleaseSource is isolated in
+	// FetchReleases.
+	if netInfoError != nil {
+		log.Error().Err(netInfoError).Msg("Could not fetch net info")
 	}
-}
-
-func GetGithubRelease(url string, target interface{}) error {
-	client := &http.Client{Timeout: 10 * time.Second}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
+	if mempoolInfoError != nil {
+		log.Error().Err(mempoolInfoError).Msg("Could not fetch mempool info")
 	}
 
-	if GithubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+GithubToken)
+	if upgradePlanError != nil {
+		log.Error().Err(upgradePlanError).Msg("Could not fetch upgrade plan")
 	}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return err
+	if govProposalsError != nil {
+		log.Error().Err(govProposalsError).Msg("Could not fetch governance proposals")
 	}
-	defer res.Body.Close()
 
-	return json.NewDecoder(res.Body).Decode(target)
+	return Data{
+		releases:     releases,
+		versionInfo:  versionInfo,
+		localStatus:  localStatus,
+		remoteStatus: remoteStatus,
+		netInfo:      netInfo,
+		mempoolInfo:  mempoolInfo,
+		upgradePlan:  upgradePlan,
+		govProposals: govProposals,
+	}
 }
 
 func GetNodeStatus(nodeUrl string) (*coretypes.ResultStatus, error) {
@@ -385,9 +882,27 @@ func GetNodeStatus(nodeUrl string) (*coretypes.ResultStatus, error) {
 	return status, nil
 }
 
-func GetBinaryVersion() (VersionInfo, error) {
-	args := strings.Split(BinaryArgs, " ")
-	out, err := exec.Command(BinaryPath, args...).CombinedOutput()
+func GetNetInfo(nodeUrl string) (*coretypes.ResultNetInfo, error) {
+	client, err := tmrpc.New(nodeUrl, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NetInfo(context.Background())
+}
+
+func GetMempoolInfo(nodeUrl string) (*coretypes.ResultUnconfirmedTxs, error) {
+	client, err := tmrpc.New(nodeUrl, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NumUnconfirmedTxs(context.Background())
+}
+
+func GetBinaryVersion(binaryPath, binaryArgs string) (VersionInfo, error) {
+	args := strings.Split(binaryArgs, " ")
+	out, err := exec.Command(binaryPath, args...).CombinedOutput()
 	if err != nil {
 		log.Error().Err(err).Str("output", string(out)).Msg("Could not get app version")
 		return VersionInfo{}, err
@@ -421,6 +936,7 @@ func getJsonString(input string) string {
 
 func main() {
 	rootCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
+	rootCmd.PersistentFlags().StringVar(&ModulesConfigPath, "modules-config", "", "Path to a YAML file defining named node profiles for /probe?module=<name>")
 	rootCmd.PersistentFlags().StringVar(&ListenAddress, "listen-address", ":9500", "The address this exporter would listen on")
 	rootCmd.PersistentFlags().StringVar(&LogLevel, "log-level", "info", "Logging level")
 	rootCmd.PersistentFlags().StringVar(&RemoteTendermintRpc, "remote-tendermint-rpc", "", "Remote Tendermint RPC address")
@@ -430,7 +946,21 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&GithubOrg, "github-org", "", "Github organization name")
 	rootCmd.PersistentFlags().StringVar(&GithubRepo, "github-repo", "", "Github repository name")
 	rootCmd.PersistentFlags().StringVar(&GithubToken, "github-token", "", "Github personal access token")
+	rootCmd.PersistentFlags().StringSliceVar(&ReleaseSources, "release-source", []string{"github"}, "Release sources to query for the latest version (github, gitlab, gitea, http)")
+	rootCmd.PersistentFlags().StringVar(&GitlabProjectId, "gitlab-project-id", "", "GitLab project ID to query releases for")
+	rootCmd.PersistentFlags().StringVar(&GitlabBaseUrl, "gitlab-base-url", "https://gitlab.com", "GitLab instance base URL")
+	rootCmd.PersistentFlags().StringVar(&GitlabToken, "gitlab-token", "", "Bearer token for --gitlab-project-id")
+	rootCmd.PersistentFlags().StringVar(&GiteaBaseUrl, "gitea-base-url", "https://gitea.com", "Gitea instance base URL")
+	rootCmd.PersistentFlags().StringVar(&GiteaOwner, "gitea-owner", "", "Gitea repository owner")
+	rootCmd.PersistentFlags().StringVar(&GiteaRepo, "gitea-repo", "", "Gitea repository name")
+	rootCmd.PersistentFlags().StringVar(&GiteaToken, "gitea-token", "", "Bearer token for --gitea-owner/--gitea-repo")
+	rootCmd.PersistentFlags().StringVar(&ReleaseUrl, "release-url", "", "Arbitrary URL returning JSON to extract the latest version from")
+	rootCmd.PersistentFlags().StringVar(&ReleaseJsonPath, "release-json-path", "", "Dot-separated JSONPath to the version field in --release-url's response")
+	rootCmd.PersistentFlags().StringVar(&HttpToken, "http-token", "", "Bearer token for --release-url")
+	rootCmd.PersistentFlags().StringVar(&CosmosRest, "cosmos-rest", "", "Cosmos SDK LCD address to query upgrade plan and governance proposals from")
+	rootCmd.PersistentFlags().BoolVar(&ConsiderPrerelease, "consider-prerelease", false, "Classify a local pre-release version as \"prerelease\" instead of comparing it numerically")
 	rootCmd.PersistentFlags().BoolVar(&JsonOutput, "json", false, "Output logs as JSON")
+	rootCmd.PersistentFlags().Int64Var(&SigningWindow, "signing-window", 100, "Number of most recent blocks to check validator signing performance over")
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal().Err(err).Msg("Could not start application")
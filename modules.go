@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NodeConfig describes a single Tendermint node to scrape: its own RPC
+// address plus everything needed to compare it against a remote node and the
+// latest release from its configured release sources. It is built either from the process-wide flags (for
+// /metrics) or from a /probe request's query string or modules config
+// entry.
+type NodeConfig struct {
+	RPC         string `yaml:"rpc"`
+	RemoteRPC   string `yaml:"remote_rpc"`
+	BinaryPath  string `yaml:"binary_path"`
+	BinaryArgs  string `yaml:"binary_args"`
+	GithubOrg   string `yaml:"github_org"`
+	GithubRepo  string `yaml:"github_repo"`
+	GithubToken string `yaml:"github_token"`
+	CosmosRest  string `yaml:"cosmos_rest"`
+
+	// ReleaseSources lists which ReleaseSource kinds to query for the
+	// latest version ("github", "gitlab", "gitea", "http"). Defaults to
+	// ["github"] when empty, to preserve the exporter's original behaviour.
+	ReleaseSources  []string `yaml:"release_sources"`
+	GitlabProjectId string   `yaml:"gitlab_project_id"`
+	GitlabBaseUrl   string   `yaml:"gitlab_base_url"`
+	GitlabToken     string   `yaml:"gitlab_token"`
+	GiteaBaseUrl    string   `yaml:"gitea_base_url"`
+	GiteaOwner      string   `yaml:"gitea_owner"`
+	GiteaRepo       string   `yaml:"gitea_repo"`
+	GiteaToken      string   `yaml:"gitea_token"`
+	ReleaseUrl      string   `yaml:"release_url"`
+	ReleaseJsonPath string   `yaml:"release_json_path"`
+	HttpToken       string   `yaml:"http_token"`
+}
+
+// ModulesConfig is the top-level shape of the YAML file passed via
+// --modules-config, mapping a module name (referenced as /probe?module=name)
+// to its NodeConfig.
+type ModulesConfig struct {
+	Modules map[string]NodeConfig `yaml:"modules"`
+}
+
+// LoadModulesConfig reads and parses the modules config file at path.
+func LoadModulesConfig(path string) (map[string]NodeConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read modules config: %w", err)
+	}
+
+	var config ModulesConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("could not parse modules config: %w", err)
+	}
+
+	return config.Modules, nil
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReleaseSource fetches the latest published version of a binary from some
+// release forge.
+type ReleaseSource interface {
+	// Name identifies the kind of source, e.g. "github", "gitlab".
+	Name() string
+	// Repo is a human-readable identifier for what's being tracked, used as
+	// the "repo" label on the exported metric.
+	Repo() string
+	// LatestVersion returns the latest released tag/version string.
+	LatestVersion() (string, error)
+}
+
+// GithubReleaseSource fetches the latest release from api.github.com.
+type GithubReleaseSource struct {
+	Org      string
+	RepoName string
+	Token    string
+}
+
+func (s *GithubReleaseSource) Name() string { return "github" }
+func (s *GithubReleaseSource) Repo() string { return s.Org + "/" + s.RepoName }
+
+func (s *GithubReleaseSource) LatestVersion() (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", s.Org, s.RepoName)
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+
+	if err := httpGetJson(url, s.Token, &release); err != nil {
+		return "", err
+	}
+
+	return release.TagName, nil
+}
+
+// GitlabReleaseSource fetches the latest release from a GitLab instance's
+// releases API.
+type GitlabReleaseSource struct {
+	BaseUrl   string
+	ProjectId string
+	Token     string
+}
+
+func (s *GitlabReleaseSource) Name() string { return "gitlab" }
+func (s *GitlabReleaseSource) Repo() string { return s.ProjectId }
+
+func (s *GitlabReleaseSource) LatestVersion() (string, error) {
+	url := fmt.Sprintf("%s/api/v4/projects/%s/releases", strings.TrimSuffix(s.BaseUrl, "/"), s.ProjectId)
+
+	var releases []struct {
+		TagName string `json:"tag_name"`
+	}
+
+	if err := httpGetJson(url, s.Token, &releases); err != nil {
+		return "", err
+	}
+
+	if len(releases) == 0 {
+		return "", fmt.Errorf("no releases found for project %s", s.ProjectId)
+	}
+
+	return releases[0].TagName, nil
+}
+
+// GiteaReleaseSource fetches the latest release from a Gitea (or Forgejo)
+// instance's releases API.
+type GiteaReleaseSource struct {
+	BaseUrl  string
+	Owner    string
+	RepoName string
+	Token    string
+}
+
+func (s *GiteaReleaseSource) Name() string { return "gitea" }
+func (s *GiteaReleaseSource) Repo() string { return s.Owner + "/" + s.RepoName }
+
+func (s *GiteaReleaseSource) LatestVersion() (string, error) {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/%s/releases/latest", strings.TrimSuffix(s.BaseUrl, "/"), s.Owner, s.RepoName)
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+
+	if err := httpGetJson(url, s.Token, &release); err != nil {
+		return "", err
+	}
+
+	return release.TagName, nil
+}
+
+// HttpReleaseSource fetches an arbitrary URL returning a JSON body and
+// extracts the version with a dot-separated JSONPath, e.g. "data.tag_name"
+// or "releases.0.tag_name".
+type HttpReleaseSource struct {
+	Url      string
+	JsonPath string
+	Token    string
+}
+
+func (s *HttpReleaseSource) Name() string { return "http" }
+func (s *HttpReleaseSource) Repo() string { return s.Url }
+
+func (s *HttpReleaseSource) LatestVersion() (string, error) {
+	var body interface{}
+	if err := httpGetJson(s.Url, s.Token, &body); err != nil {
+		return "", err
+	}
+
+	return jsonPathLookup(body, s.JsonPath)
+}
+
+// httpGetJson fetches url with an optional bearer token and decodes the JSON
+// response body into target.
+func httpGetJson(url string, token string, target interface{}) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(target)
+}
+
+// jsonPathLookup walks a dot-separated path (e.g. "data.releases.0.tag")
+// through a value decoded from JSON, returning the leaf as a string.
+func jsonPathLookup(value interface{}, path string) (string, error) {
+	current := value
+
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[key]
+			if !ok {
+				return "", fmt.Errorf("key %q not found in JSON response", key)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", fmt.Errorf("invalid array index %q in JSON response", key)
+			}
+			current = node[index]
+		default:
+			return "", fmt.Errorf("cannot descend into %q: not an object or array", key)
+		}
+	}
+
+	switch leaf := current.(type) {
+	case string:
+		return leaf, nil
+	case fmt.Stringer:
+		return leaf.String(), nil
+	default:
+		return fmt.Sprintf("%v", leaf), nil
+	}
+}
+
+// BuildReleaseSources constructs one ReleaseSource per kind listed in
+// cfg.ReleaseSources, skipping any kind whose required fields are not set.
+func BuildReleaseSources(cfg NodeConfig) []ReleaseSource {
+	kinds := cfg.ReleaseSources
+	if len(kinds) == 0 {
+		kinds = []string{"github"}
+	}
+
+	var sources []ReleaseSource
+
+	for _, kind := range kinds {
+		switch kind {
+		case "github":
+			if cfg.GithubOrg == "" || cfg.GithubRepo == "" {
+				log.Debug().Msg("No GitHub org or repo set, not requesting latest binary version.")
+				continue
+			}
+			sources = append(sources, &GithubReleaseSource{Org: cfg.GithubOrg, RepoName: cfg.GithubRepo, Token: cfg.GithubToken})
+		case "gitlab":
+			if cfg.GitlabProjectId == "" {
+				log.Debug().Msg("No GitLab project ID set, not requesting latest binary version from GitLab.")
+				continue
+			}
+			baseUrl := cfg.GitlabBaseUrl
+			if baseUrl == "" {
+				baseUrl = "https://gitlab.com"
+			}
+			sources = append(sources, &GitlabReleaseSource{BaseUrl: baseUrl, ProjectId: cfg.GitlabProjectId, Token: cfg.GitlabToken})
+		case "gitea":
+			if cfg.GiteaOwner == "" || cfg.GiteaRepo == "" {
+				log.Debug().Msg("No Gitea owner/repo set, not requesting latest binary version from Gitea.")
+				continue
+			}
+			baseUrl := cfg.GiteaBaseUrl
+			if baseUrl == "" {
+				baseUrl = "https://gitea.com"
+			}
+			sources = append(sources, &GiteaReleaseSource{BaseUrl: baseUrl, Owner: cfg.GiteaOwner, RepoName: cfg.GiteaRepo, Token: cfg.GiteaToken})
+		case "http":
+			if cfg.ReleaseUrl == "" {
+				log.Debug().Msg("No release URL set, not requesting latest binary version over HTTP.")
+				continue
+			}
+			sources = append(sources, &HttpReleaseSource{Url: cfg.ReleaseUrl, JsonPath: cfg.ReleaseJsonPath, Token: cfg.HttpToken})
+		default:
+			log.Error().Str("kind", kind).Msg("Unknown release source kind")
+		}
+	}
+
+	return sources
+}
+
+// ReleaseResult is what one ReleaseSource resolved to, ready to be set on
+// the tendermint_release_latest_version gauge.
+type ReleaseResult struct {
+	Source  string
+	Repo    string
+	Version string
+}
+
+// FetchReleases queries every release source configured for cfg in
+// parallel. A source that errors is logged and omitted from the result,
+// rather than failing the whole scrape. The returned slice preserves the
+// order of cfg.ReleaseSources (not fetch-completion order), since callers
+// such as the version-staleness gauges treat the first entry as primary.
+func FetchReleases(cfg NodeConfig) []ReleaseResult {
+	sources := BuildReleaseSources(cfg)
+
+	var wg sync.WaitGroup
+
+	results := make([]*ReleaseResult, len(sources))
+
+	for i, source := range sources {
+		i, source := i, source
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			version, err := source.LatestVersion()
+			if err != nil {
+				log.Error().Err(err).Str("source", source.Name()).Str("repo", source.Repo()).Msg("Could not fetch latest release")
+				return
+			}
+
+			results[i] = &ReleaseResult{Source: source.Name(), Repo: source.Repo(), Version: version}
+		}()
+	}
+
+	wg.Wait()
+
+	ordered := make([]ReleaseResult, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			ordered = append(ordered, *result)
+		}
+	}
+
+	return ordered
+}
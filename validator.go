@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmrpc "github.com/tendermint/tendermint/rpc/client/http"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// slotStatus classifies how a validator behaved at a single block height.
+type slotStatus int
+
+const (
+	slotSigned slotStatus = iota
+	slotNil
+	slotAbsent
+)
+
+// signingRecord is what gets cached per height for a given validator.
+type signingRecord struct {
+	status   slotStatus
+	proposer string
+}
+
+// validatorStats is the in-memory ring buffer of signing records for a
+// single validator address, plus the running totals that are never
+// pruned. Heights outside the current signing window are dropped so
+// successive scrapes only need to fetch the new tip blocks.
+type validatorStats struct {
+	mu sync.Mutex
+
+	records map[int64]signingRecord
+
+	precommitAbsentTotal float64
+	proposedBlocksTotal  map[string]float64
+}
+
+var (
+	validatorStatsMu  sync.Mutex
+	validatorStatsFor = map[string]*validatorStats{}
+)
+
+// statsForValidator returns the cache entry for the validator address on
+// rpcUrl, creating it on first use. The cache is scoped by rpcUrl as well as
+// address so that the same validator key reused across multiple chains (or
+// a validator's testnet/mainnet instances) does not share signing records.
+func statsForValidator(rpcUrl string, address string) *validatorStats {
+	validatorStatsMu.Lock()
+	defer validatorStatsMu.Unlock()
+
+	key := rpcUrl + "|" + address
+
+	stats, ok := validatorStatsFor[key]
+	if !ok {
+		stats = &validatorStats{
+			records:             map[int64]signingRecord{},
+			proposedBlocksTotal: map[string]float64{},
+		}
+		validatorStatsFor[key] = stats
+	}
+
+	return stats
+}
+
+// ValidatorSigningResult is the outcome of a signing-window scrape for a
+// single validator, ready to be set on gauges.
+type ValidatorSigningResult struct {
+	Signed               int64
+	Missed               int64
+	PrecommitAbsentTotal float64
+	ProposedBlocksTotal  map[string]float64
+}
+
+// GetValidatorSigningInfo walks the last `window` blocks (up to and including
+// latestHeight) over the Tendermint RPC Commit and Block endpoints and
+// classifies each one as signed, nil, or absent for the given validator
+// address. Heights already present in the ring buffer are not re-fetched.
+func GetValidatorSigningInfo(rpcUrl string, validatorAddress crypto.Address, latestHeight int64, window int64) (ValidatorSigningResult, error) {
+	client, err := tmrpc.New(rpcUrl, "/websocket")
+	if err != nil {
+		return ValidatorSigningResult{}, err
+	}
+
+	address := validatorAddress.String()
+	stats := statsForValidator(rpcUrl, address)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	from := latestHeight - window + 1
+	if from < 1 {
+		from = 1
+	}
+
+	ctx := context.Background()
+
+	for height := from; height <= latestHeight; height++ {
+		if _, ok := stats.records[height]; ok {
+			continue
+		}
+
+		record, err := fetchSigningRecord(ctx, client, validatorAddress, height)
+		if err != nil {
+			return ValidatorSigningResult{}, fmt.Errorf("could not fetch signing record for height %d: %w", height, err)
+		}
+
+		stats.records[height] = record
+
+		if record.status == slotAbsent {
+			stats.precommitAbsentTotal++
+		}
+
+		if record.proposer != "" {
+			stats.proposedBlocksTotal[record.proposer]++
+		}
+	}
+
+	for height := range stats.records {
+		if height < from {
+			delete(stats.records, height)
+		}
+	}
+
+	var signed, missed int64
+	for height := from; height <= latestHeight; height++ {
+		if stats.records[height].status == slotSigned {
+			signed++
+		} else {
+			missed++
+		}
+	}
+
+	proposedBlocksTotal := make(map[string]float64, len(stats.proposedBlocksTotal))
+	for proposer, count := range stats.proposedBlocksTotal {
+		proposedBlocksTotal[proposer] = count
+	}
+
+	return ValidatorSigningResult{
+		Signed:               signed,
+		Missed:               missed,
+		PrecommitAbsentTotal: stats.precommitAbsentTotal,
+		ProposedBlocksTotal:  proposedBlocksTotal,
+	}, nil
+}
+
+// fetchSigningRecord fetches the commit and block at height and classifies
+// how validatorAddress behaved there.
+func fetchSigningRecord(ctx context.Context, client *tmrpc.HTTP, validatorAddress crypto.Address, height int64) (signingRecord, error) {
+	h := height
+
+	commit, err := client.Commit(ctx, &h)
+	if err != nil {
+		return signingRecord{}, err
+	}
+
+	block, err := client.Block(ctx, &h)
+	if err != nil {
+		return signingRecord{}, err
+	}
+
+	var proposer string
+	if block.Block != nil {
+		proposer = block.Block.Header.ProposerAddress.String()
+	}
+
+	status := slotAbsent
+	for _, sig := range commit.Commit.Signatures {
+		if !bytes.Equal(sig.ValidatorAddress, validatorAddress) {
+			continue
+		}
+
+		switch sig.BlockIDFlag {
+		case tmtypes.BlockIDFlagCommit:
+			status = slotSigned
+		case tmtypes.BlockIDFlagNil:
+			status = slotNil
+		default:
+			status = slotAbsent
+		}
+
+		break
+	}
+
+	return signingRecord{status: status, proposer: proposer}, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a minimal parsed representation of a semantic version, covering
+// just what we need to compare two tags: major/minor/patch plus whether a
+// pre-release suffix is present.
+type semver struct {
+	major, minor, patch int64
+	preRelease          string
+}
+
+// parseSemver parses a version string, tolerating a leading "v" and ignoring
+// build metadata (the "+..." suffix). Pre-release identifiers (the
+// "-..." suffix) are kept so callers can flag prerelease versions, but are
+// not otherwise compared.
+func parseSemver(version string) (semver, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if idx := strings.IndexByte(v, '+'); idx != -1 {
+		v = v[:idx]
+	}
+
+	var preRelease string
+	if idx := strings.IndexByte(v, '-'); idx != -1 {
+		preRelease = v[idx+1:]
+		v = v[:idx]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return semver{}, fmt.Errorf("expected major.minor.patch, got %q", version)
+	}
+
+	numbers := make([]int64, 3)
+	for i, part := range parts {
+		number, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return semver{}, fmt.Errorf("could not parse %q as a number: %w", part, err)
+		}
+		numbers[i] = number
+	}
+
+	return semver{
+		major:      numbers[0],
+		minor:      numbers[1],
+		patch:      numbers[2],
+		preRelease: preRelease,
+	}, nil
+}
+
+// versionDelta is the signed difference between a local and a remote
+// semver, one entry per major/minor/patch component.
+type versionDelta struct {
+	major, minor, patch int64
+}
+
+// versionStatus classifies how a local version compares to a remote one.
+type versionStatus string
+
+const (
+	versionStatusUpToDate   versionStatus = "up_to_date"
+	versionStatusBehind     versionStatus = "behind"
+	versionStatusAhead      versionStatus = "ahead"
+	versionStatusPrerelease versionStatus = "prerelease"
+)
+
+// compareSemver returns the delta between local and remote and classifies
+// the overall status. A non-empty pre-release on the local version only
+// classifies as "prerelease" when considerPrerelease is set; otherwise the
+// pre-release suffix is ignored and the versions are compared purely
+// numerically, to match how most registries advertise the latest stable tag.
+func compareSemver(local, remote semver, considerPrerelease bool) (versionDelta, versionStatus) {
+	delta := versionDelta{
+		major: local.major - remote.major,
+		minor: local.minor - remote.minor,
+		patch: local.patch - remote.patch,
+	}
+
+	if considerPrerelease && local.preRelease != "" {
+		return delta, versionStatusPrerelease
+	}
+
+	switch {
+	case delta.major == 0 && delta.minor == 0 && delta.patch == 0:
+		return delta, versionStatusUpToDate
+	case delta.major > 0 || (delta.major == 0 && delta.minor > 0) || (delta.major == 0 && delta.minor == 0 && delta.patch > 0):
+		return delta, versionStatusAhead
+	default:
+		return delta, versionStatusBehind
+	}
+}